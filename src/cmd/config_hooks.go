@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Originate/git-town/src/git"
+	"github.com/Originate/git-town/src/hooks"
+	"github.com/Originate/git-town/src/util"
+
+	"github.com/spf13/cobra"
+)
+
+var configHooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Manage lifecycle hooks",
+}
+
+var configHooksAddCmd = &cobra.Command{
+	Use:   "add <event> <command>",
+	Short: "Register a shell command to run at a lifecycle event",
+	Long: `Register a shell command to run at a lifecycle event
+
+Supported events: pre-ship, post-ship (fired by "git town ship") and
+pre-sync, post-sync (fired by "git town sync-all"). There is no "hack"
+or "kill" command in this version of git-town yet, so no event fires
+around those.
+
+The command is appended to .git-town.yml and is evaluated as a Go
+template with {{.Branch}}, {{.Parent}}, {{.Main}}, {{.Command}},
+{{.Event}}, and {{.CommitMessage}} available, e.g.
+"curl -XPOST .../deploy?branch={{.Branch}}".`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		git.EnsureIsRepository()
+		if err := hooks.Add(".", args[0], args[1]); err != nil {
+			util.ExitWithErrorMessage(err.Error())
+		}
+		fmt.Printf("Registered %q hook: %s\n", args[0], args[1])
+	},
+}
+
+func init() {
+	configHooksCmd.AddCommand(configHooksAddCmd)
+	configCmd.AddCommand(configHooksCmd)
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/Originate/git-town/src/git"
+	"github.com/Originate/git-town/src/hooks"
 	"github.com/Originate/git-town/src/prompt"
 	"github.com/Originate/git-town/src/script"
 	"github.com/Originate/git-town/src/steps"
@@ -20,6 +21,15 @@ type shipConfig struct {
 
 var commitMessage string
 
+// noAPIFlag forces "git town ship" to perform a local squash-merge even
+// when ship.strategy would otherwise use the forge's API.
+var noAPIFlag bool
+
+// pushToFlag overrides the remote "git town ship" pushes the main branch
+// to, instead of the branch's configured push target (see
+// git.PushTarget).
+var pushToFlag string
+
 var shipCmd = &cobra.Command{
 	Use:   "ship",
 	Short: "Deliver a completed feature branch",
@@ -70,7 +80,7 @@ func checkShipPreconditions(args []string) (result shipConfig) {
 	if result.BranchToShip == result.InitialBranch {
 		git.EnsureDoesNotHaveOpenChanges("Did you mean to commit them before shipping?")
 	}
-	if git.HasRemote("origin") && !git.IsOffline() {
+	if git.HasRemote(pushRemote(git.GetMainBranch())) && !git.IsOffline() {
 		script.Fetch()
 	}
 	if result.BranchToShip != result.InitialBranch {
@@ -98,16 +108,30 @@ func getShipStepList(config shipConfig) (result steps.StepList) {
 	var isOffline = git.IsOffline()
 	mainBranch := git.GetMainBranch()
 	isShippingInitialBranch := config.BranchToShip == config.InitialBranch
+	hookData := hooks.Data{
+		Branch:        config.BranchToShip,
+		Parent:        git.GetParentBranch(config.BranchToShip),
+		Main:          mainBranch,
+		Command:       "ship",
+		CommitMessage: commitMessage,
+	}
+	preShipData := hookData
+	preShipData.Event = "pre-ship"
+	result.Append(steps.RunHookStep{Event: "pre-ship", Data: preShipData})
 	result.AppendList(steps.GetSyncBranchSteps(mainBranch))
 	result.Append(steps.CheckoutBranchStep{BranchName: config.BranchToShip})
 	result.Append(steps.MergeTrackingBranchStep{})
 	result.Append(steps.MergeBranchStep{BranchName: mainBranch})
 	result.Append(steps.EnsureHasShippableChangesStep{BranchName: config.BranchToShip})
 	result.Append(steps.CheckoutBranchStep{BranchName: mainBranch})
-	result.Append(steps.SquashMergeBranchStep{BranchName: config.BranchToShip, CommitMessage: commitMessage})
-	if git.HasRemote("origin") && !isOffline {
-		result.Append(steps.PushBranchStep{BranchName: mainBranch, Undoable: true})
-	}
+	remote := pushRemote(mainBranch)
+	result.Append(steps.ShipStep{
+		BranchName:    config.BranchToShip,
+		TargetBranch:  mainBranch,
+		Remote:        pushableRemote(remote, isOffline),
+		CommitMessage: commitMessage,
+		Strategy:      shipStrategy(remote, isOffline),
+	})
 	childBranches := git.GetChildBranches(config.TargetBranch)
 	if git.HasTrackingBranch(config.TargetBranch) && len(childBranches) == 0 && !isOffline {
 		result.Append(steps.DeleteRemoteBranchStep{BranchName: config.BranchToShip, IsTracking: true})
@@ -121,14 +145,52 @@ func getShipStepList(config shipConfig) (result steps.StepList) {
 	if !isShippingInitialBranch {
 		result.Append(steps.CheckoutBranchStep{BranchName: config.InitialBranch})
 	}
+	postShipData := hookData
+	postShipData.Event = "post-ship"
+	result.Append(steps.RunHookStep{Event: "post-ship", Data: postShipData})
 	result.Wrap(steps.WrapOptions{RunInGitRoot: true, StashOpenChanges: !isShippingInitialBranch})
 	return
 }
 
+// shipStrategy returns the "ship.strategy" that ShipStep should use for
+// this invocation: "squash-merge" when the API can't or shouldn't be
+// tried (the "--no-api" flag, no remote, or offline), and the configured
+// strategy otherwise. It only inspects local, side-effect-free state, so
+// it's safe to call while building the step list; the forge lookup this
+// may lead to happens later, inside ShipStep.Run().
+func shipStrategy(remote string, isOffline bool) string {
+	if noAPIFlag || isOffline || !git.HasRemote(remote) {
+		return "squash-merge"
+	}
+	return git.GetShipStrategy()
+}
+
+// pushableRemote returns remote, unless there's nothing to push to
+// (no such remote configured, or offline), in which case it returns ""
+// so that ShipStep skips the push after a local squash-merge.
+func pushableRemote(remote string, isOffline bool) string {
+	if isOffline || !git.HasRemote(remote) {
+		return ""
+	}
+	return remote
+}
+
+// pushRemote returns the remote "git town ship" should push branchName
+// to: the "--push-to" override if given, otherwise the branch's
+// configured push target.
+func pushRemote(branchName string) string {
+	if pushToFlag != "" {
+		return pushToFlag
+	}
+	return git.PushTarget(branchName).Remote
+}
+
 func init() {
 	shipCmd.Flags().BoolVar(&abortFlag, "abort", false, abortFlagDescription)
 	shipCmd.Flags().StringVarP(&commitMessage, "message", "m", "", "Specify the commit message for the squash commit")
 	shipCmd.Flags().BoolVar(&continueFlag, "continue", false, continueFlagDescription)
 	shipCmd.Flags().BoolVar(&undoFlag, "undo", false, undoFlagDescription)
+	shipCmd.Flags().BoolVar(&noAPIFlag, "no-api", false, "Ship with a local squash-merge even if a pull request exists")
+	shipCmd.Flags().StringVar(&pushToFlag, "push-to", "", "Push to this remote instead of the branch's configured push target")
 	RootCmd.AddCommand(shipCmd)
 }
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"github.com/Originate/git-town/src/exit"
+	"github.com/Originate/git-town/src/git"
+	"github.com/Originate/git-town/src/hooks"
+	"github.com/Originate/git-town/src/prompt"
+	"github.com/Originate/git-town/src/script"
+	"github.com/Originate/git-town/src/steps"
+
+	"github.com/spf13/cobra"
+)
+
+var syncAllCmd = &cobra.Command{
+	Use:   "sync-all",
+	Short: "Update all local branches with remote changes",
+	Long: `Update all local branches with remote changes
+
+Fetches and prunes the remote, then for every local branch:
+
+- fast-forwards it to its upstream, if the upstream still exists and the
+  branch has no unpushed commits
+- deletes it, and removes its entry from git-town's parent-branch
+  configuration, if its upstream is gone and it has already been merged
+  into the main branch
+- leaves it alone and reports it if its upstream is gone but the branch
+  still has unmerged work
+
+The branch that was checked out before running this command is checked
+out again at the end.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		git.EnsureIsRepository()
+		prompt.EnsureIsConfigured()
+		steps.Run(steps.RunOptions{
+			CanSkip:              func() bool { return false },
+			Command:              "sync-all",
+			IsAbort:              abortFlag,
+			IsContinue:           continueFlag,
+			IsSkip:               false,
+			IsUndo:               undoFlag,
+			SkipMessageGenerator: func() string { return "" },
+			StepListGenerator: func() steps.StepList {
+				checkSyncAllPreconditions()
+				return getSyncAllStepList()
+			},
+		})
+	},
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return validateMaxArgs(args, 0)
+	},
+}
+
+func checkSyncAllPreconditions() {
+	if git.HasRemote("origin") && !git.IsOffline() {
+		exit.On(script.NewGitCmd("fetch").Arg("--prune").Run())
+	}
+}
+
+func getSyncAllStepList() (result steps.StepList) {
+	initialBranch := git.GetCurrentBranchName()
+	mainBranch := git.GetMainBranch()
+	hookData := hooks.Data{Main: mainBranch, Command: "sync-all"}
+	preSyncData := hookData
+	preSyncData.Event = "pre-sync"
+	result.Append(steps.RunHookStep{Event: "pre-sync", Data: preSyncData})
+	// finalBranch is checked out once all branches are synced. It falls
+	// back to the main branch if the branch that was checked out before
+	// "sync-all" started got pruned along the way.
+	finalBranch := initialBranch
+	for _, branchName := range git.GetLocalBranches() {
+		branchSteps, deletesBranch := steps.GetSyncAllBranchSteps(branchName, mainBranch, initialBranch)
+		result.AppendList(branchSteps)
+		if deletesBranch && branchName == finalBranch {
+			finalBranch = mainBranch
+		}
+	}
+	result.Append(steps.CheckoutBranchStep{BranchName: finalBranch})
+	postSyncData := hookData
+	postSyncData.Event = "post-sync"
+	result.Append(steps.RunHookStep{Event: "post-sync", Data: postSyncData})
+	result.Wrap(steps.WrapOptions{RunInGitRoot: true, StashOpenChanges: true})
+	return
+}
+
+func init() {
+	syncAllCmd.Flags().BoolVar(&abortFlag, "abort", false, abortFlagDescription)
+	syncAllCmd.Flags().BoolVar(&continueFlag, "continue", false, continueFlagDescription)
+	syncAllCmd.Flags().BoolVar(&undoFlag, "undo", false, undoFlagDescription)
+	RootCmd.AddCommand(syncAllCmd)
+}
@@ -0,0 +1,29 @@
+// Package forge talks to the code-hosting API backing a repository's
+// "origin" remote, so commands like "git town ship" can merge a
+// pull/merge request through the API instead of pushing a local
+// squash-merge. Only GitHub hosts have a Driver; for any other host
+// (GitLab, Bitbucket, ...) DriverForOrigin returns an error.
+package forge
+
+// PullRequest is a minimal, forge-agnostic view of an open pull or merge
+// request.
+type PullRequest struct {
+	Number int
+	Title  string
+	Body   string
+}
+
+// Driver is implemented by each supported forge.
+type Driver interface {
+	// FindPullRequest returns the open pull/merge request from
+	// branchName into targetBranch, or nil if there is none.
+	FindPullRequest(branchName, targetBranch string) (*PullRequest, error)
+
+	// SquashMergePullRequest squash-merges the given pull/merge request
+	// using the given commit message.
+	SquashMergePullRequest(number int, commitMessage string) error
+
+	// ClosePullRequest closes the given pull/merge request without
+	// merging it.
+	ClosePullRequest(number int) error
+}
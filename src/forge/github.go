@@ -0,0 +1,106 @@
+package forge
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GitHubDriver talks to the GitHub REST API.
+type GitHubDriver struct {
+	apiBaseURL string
+	owner      string
+	repo       string
+	token      string
+}
+
+// NewGitHubDriver creates a Driver for the GitHub (or GitHub Enterprise)
+// instance at the given host.
+func NewGitHubDriver(host, owner, repo, token string) *GitHubDriver {
+	apiBaseURL := "https://api.github.com"
+	if host != "github.com" {
+		apiBaseURL = "https://" + host + "/api/v3"
+	}
+	return &GitHubDriver{apiBaseURL: apiBaseURL, owner: owner, repo: repo, token: token}
+}
+
+type githubPullRequest struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	Head   struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+func (d *GitHubDriver) FindPullRequest(branchName, targetBranch string) (*PullRequest, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls?head=%s:%s&base=%s&state=open", d.apiBaseURL, d.owner, d.repo, d.owner, branchName, targetBranch)
+	var pullRequests []githubPullRequest
+	if err := d.request(http.MethodGet, url, nil, &pullRequests); err != nil {
+		return nil, err
+	}
+	if len(pullRequests) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	found := pullRequests[0]
+	return &PullRequest{Number: found.Number, Title: found.Title, Body: found.Body}, nil
+}
+
+func (d *GitHubDriver) SquashMergePullRequest(number int, commitMessage string) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/merge", d.apiBaseURL, d.owner, d.repo, number)
+	body := map[string]string{
+		"commit_title":   firstLine(commitMessage),
+		"commit_message": commitMessage,
+		"merge_method":   "squash",
+	}
+	return d.request(http.MethodPut, url, body, nil)
+}
+
+func (d *GitHubDriver) ClosePullRequest(number int) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", d.apiBaseURL, d.owner, d.repo, number)
+	return d.request(http.MethodPatch, url, map[string]string{"state": "closed"}, nil)
+}
+
+func (d *GitHubDriver) request(method, url string, body, result interface{}) error {
+	var reader *bytes.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	request, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "token "+d.token)
+	request.Header.Set("Accept", "application/vnd.github.v3+json")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("GitHub API request %s %s failed with status %s", method, url, response.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+func firstLine(text string) string {
+	for index, char := range text {
+		if char == '\n' {
+			return text[:index]
+		}
+	}
+	return text
+}
@@ -0,0 +1,84 @@
+package forge
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Originate/git-town/src/git"
+	"github.com/Originate/git-town/src/prompt"
+)
+
+// scpLikeURL matches SSH remotes in the "git@host:owner/repo.git" form
+// that don't parse as a regular URL.
+var scpLikeURL = regexp.MustCompile(`^[\w-]+@([^:]+):(.+?)(\.git)?$`)
+
+// DriverForOrigin inspects the "origin" remote and returns the Driver
+// that can talk to the forge hosting it, prompting for and caching a
+// token if none of the standard sources has one. Only call this once the
+// caller has already committed to using the forge API, since it can
+// interrupt the user.
+func DriverForOrigin() (Driver, error) {
+	host, owner, repo, err := parseOriginURL(git.GetRemoteOriginURL())
+	if err != nil {
+		return nil, err
+	}
+	token, err := prompt.EnsureHasForgeToken(host)
+	if err != nil {
+		return nil, err
+	}
+	return driverForHost(host, owner, repo, token)
+}
+
+// DriverForOriginIfTokenAvailable behaves like DriverForOrigin but never
+// prompts: it reports ok=false when no token is available from the
+// non-interactive sources (env vars, ~/.netrc, the http.cookiefile), so
+// callers that are merely probing for an optional API integration can
+// silently fall back instead of interrupting the user.
+func DriverForOriginIfTokenAvailable() (driver Driver, ok bool, err error) {
+	host, owner, repo, err := parseOriginURL(git.GetRemoteOriginURL())
+	if err != nil {
+		return nil, false, err
+	}
+	token, ok := prompt.LookupForgeToken(host)
+	if !ok {
+		return nil, false, nil
+	}
+	driver, err = driverForHost(host, owner, repo, token)
+	return driver, err == nil, err
+}
+
+func driverForHost(host, owner, repo, token string) (Driver, error) {
+	switch {
+	case strings.Contains(host, "github.com"):
+		return NewGitHubDriver(host, owner, repo, token), nil
+	default:
+		return nil, fmt.Errorf("git town doesn't know how to talk to the API of %q yet", host)
+	}
+}
+
+// parseOriginURL extracts the host, owner, and repository name from a
+// remote URL, accepting both "https://host/owner/repo.git" and
+// "git@host:owner/repo.git" forms.
+func parseOriginURL(originURL string) (host, owner, repo string, err error) {
+	if match := scpLikeURL.FindStringSubmatch(originURL); match != nil {
+		return splitOwnerRepo(match[1], match[2])
+	}
+	trimmed := strings.TrimSuffix(originURL, ".git")
+	for _, prefix := range []string{"https://", "http://", "ssh://git@"} {
+		trimmed = strings.TrimPrefix(trimmed, prefix)
+	}
+	parts := strings.SplitN(trimmed, "/", 2)
+	if len(parts) != 2 {
+		return "", "", "", fmt.Errorf("cannot determine the forge host and repository from remote %q", originURL)
+	}
+	return splitOwnerRepo(parts[0], parts[1])
+}
+
+func splitOwnerRepo(host, ownerAndRepo string) (string, string, string, error) {
+	parts := strings.SplitN(strings.TrimSuffix(ownerAndRepo, ".git"), "/", 2)
+	if len(parts) != 2 {
+		return "", "", "", fmt.Errorf("cannot determine the owner and repository from %q", ownerAndRepo)
+	}
+	return host, parts[0], parts[1], nil
+}
@@ -0,0 +1,39 @@
+package forge
+
+import "testing"
+
+func TestParseOriginURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		originURL string
+		host      string
+		owner     string
+		repo      string
+		wantErr   bool
+	}{
+		{"https with .git", "https://github.com/acme/widget.git", "github.com", "acme", "widget", false},
+		{"https without .git", "https://github.com/acme/widget", "github.com", "acme", "widget", false},
+		{"scp-like ssh", "git@github.com:acme/widget.git", "github.com", "acme", "widget", false},
+		{"ssh scheme", "ssh://git@github.com/acme/widget.git", "github.com", "acme", "widget", false},
+		{"github enterprise", "https://git.acme.internal/acme/widget.git", "git.acme.internal", "acme", "widget", false},
+		{"malformed", "not-a-url", "", "", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			host, owner, repo, err := parseOriginURL(test.originURL)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %q, got none", test.originURL)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error for %q: %v", test.originURL, err)
+			}
+			if host != test.host || owner != test.owner || repo != test.repo {
+				t.Errorf("parseOriginURL(%q) = (%q, %q, %q), want (%q, %q, %q)",
+					test.originURL, host, owner, repo, test.host, test.owner, test.repo)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// runOutput runs "git <args...>" and returns its trimmed stdout, or ""
+// if the command fails (e.g. because the config key being looked up
+// isn't set). It is a private, output-only runner for config lookups
+// inside this package, which the script package cannot be used for:
+// script imports git (PrintCommand calls IsRepository/
+// GetCurrentBranchName), so git importing script back would be an
+// import cycle.
+func runOutput(args ...string) string {
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,30 @@
+package git
+
+// PushTargetResult is the remote (and, once one exists, the upstream ref
+// in "branch.<name>.merge") that "git push" should use for a branch.
+type PushTargetResult struct {
+	Remote string
+	Merge  string
+}
+
+// PushTarget returns the remote a branch should be pushed to, honoring
+// (in order) the branch's own "branch.<name>.remote"/"branch.<name>.merge"
+// upstream and the git-town-specific "git-town.push-remote" override.
+// With neither set, it falls back to "origin". "push.default" is not
+// consulted: it only changes what argument-less "git push" does, and
+// every push git-town issues already names an explicit remote and
+// branch, so "push.default=nothing" (which just means "don't guess a
+// target for argument-less push") has nothing to say about it.
+func PushTarget(branchName string) PushTargetResult {
+	if remote := configValue("branch." + branchName + ".remote"); remote != "" {
+		return PushTargetResult{Remote: remote, Merge: configValue("branch." + branchName + ".merge")}
+	}
+	if remote := configValue("git-town.push-remote"); remote != "" {
+		return PushTargetResult{Remote: remote}
+	}
+	return PushTargetResult{Remote: "origin"}
+}
+
+func configValue(key string) string {
+	return runOutput("config", "--get", key)
+}
@@ -0,0 +1,79 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+)
+
+// initTestRepo creates a throwaway git repository in a temp dir and
+// returns a function that runs "git" with that dir as the working
+// directory, for asserting on config-driven behavior like PushTarget.
+func initTestRepo(t *testing.T) func(args ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+		return string(output)
+	}
+	run("init", "--quiet")
+	chdir(t, dir)
+	return run
+}
+
+// chdir switches the process into dir for the duration of the test,
+// since PushTarget shells out to "git" in the current directory.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	previous, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(previous) })
+}
+
+func TestPushTarget_DefaultsToOrigin(t *testing.T) {
+	initTestRepo(t)
+	result := PushTarget("feature")
+	if result.Remote != "origin" {
+		t.Errorf("expected origin, got %q", result.Remote)
+	}
+}
+
+func TestPushTarget_IgnoresPushDefaultNothing(t *testing.T) {
+	run := initTestRepo(t)
+	run("config", "push.default", "nothing")
+	result := PushTarget("feature")
+	if result.Remote != "origin" {
+		t.Errorf("push.default=nothing must not suppress the explicit push target, got %q", result.Remote)
+	}
+}
+
+func TestPushTarget_PushRemoteOverridesOrigin(t *testing.T) {
+	run := initTestRepo(t)
+	run("config", "git-town.push-remote", "fork")
+	result := PushTarget("feature")
+	if result.Remote != "fork" {
+		t.Errorf("expected fork, got %q", result.Remote)
+	}
+}
+
+func TestPushTarget_BranchUpstreamTakesPriority(t *testing.T) {
+	run := initTestRepo(t)
+	run("config", "git-town.push-remote", "fork")
+	run("config", "branch.feature.remote", "upstream")
+	run("config", "branch.feature.merge", "refs/heads/feature")
+	result := PushTarget("feature")
+	if result.Remote != "upstream" || result.Merge != "refs/heads/feature" {
+		t.Errorf("expected upstream/refs/heads/feature, got %+v", result)
+	}
+}
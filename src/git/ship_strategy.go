@@ -0,0 +1,10 @@
+package git
+
+// GetShipStrategy returns the configured "ship.strategy" ("api",
+// "squash-merge", or "auto"), defaulting to "auto" when unset.
+func GetShipStrategy() string {
+	if strategy := configValue("ship.strategy"); strategy != "" {
+		return strategy
+	}
+	return "auto"
+}
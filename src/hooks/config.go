@@ -0,0 +1,58 @@
+package hooks
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+const configFileName = ".git-town.yml"
+
+// Config is the "hooks:" section of .git-town.yml, mapping lifecycle
+// events to the shell commands that run at them, in registration order.
+type Config struct {
+	Hooks map[string][]string `yaml:"hooks"`
+}
+
+// Load reads the hooks configuration from .git-town.yml in repoRoot. A
+// missing file yields an empty, valid configuration.
+func Load(repoRoot string) (Config, error) {
+	content, err := ioutil.ReadFile(filepath.Join(repoRoot, configFileName))
+	if os.IsNotExist(err) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, err
+	}
+	var config Config
+	if err = yaml.Unmarshal(content, &config); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// CommandsFor returns the hook commands registered for the given
+// lifecycle event, in the order they should run.
+func (c Config) CommandsFor(event string) []string {
+	return c.Hooks[event]
+}
+
+// Add registers command for event in repoRoot's .git-town.yml, creating
+// the file if it doesn't exist yet.
+func Add(repoRoot, event, command string) error {
+	config, err := Load(repoRoot)
+	if err != nil {
+		return err
+	}
+	if config.Hooks == nil {
+		config.Hooks = map[string][]string{}
+	}
+	config.Hooks[event] = append(config.Hooks[event], command)
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(repoRoot, configFileName), content, 0644)
+}
@@ -0,0 +1,30 @@
+// Package hooks lets users register shell commands that run at named
+// lifecycle points inside git-town's step execution, e.g. "pre-ship" or
+// "post-sync", configured in a repository's .git-town.yml.
+package hooks
+
+// Data is the fixed set of fields exposed to hook commands as a Go
+// template (see Evaluate), and, except for CommitMessage, as
+// "GIT_TOWN_*" environment variables (see Env), so the hook surface
+// stays stable across releases even as git-town's internal structs
+// change.
+type Data struct {
+	Branch        string
+	Parent        string
+	Main          string
+	Command       string
+	Event         string
+	CommitMessage string
+}
+
+// Env returns Data as "GIT_TOWN_*" environment variable assignments.
+// CommitMessage is not included.
+func (d Data) Env() []string {
+	return []string{
+		"GIT_TOWN_BRANCH=" + d.Branch,
+		"GIT_TOWN_PARENT=" + d.Parent,
+		"GIT_TOWN_MAIN=" + d.Main,
+		"GIT_TOWN_COMMAND=" + d.Command,
+		"GIT_TOWN_EVENT=" + d.Event,
+	}
+}
@@ -0,0 +1,22 @@
+package hooks
+
+import (
+	"bytes"
+	"text/template"
+)
+
+// Evaluate expands a hook command string as a Go template against data,
+// so users can write commands like
+// "curl -XPOST .../deploy?branch={{.Branch}}" without depending on any
+// of git-town's internal structs.
+func Evaluate(command string, data Data) (string, error) {
+	tmpl, err := template.New("hook").Parse(command)
+	if err != nil {
+		return "", err
+	}
+	var buffer bytes.Buffer
+	if err = tmpl.Execute(&buffer, data); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,170 @@
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Originate/git-town/src/script"
+)
+
+// EnsureHasForgeToken returns an API token for the given forge host,
+// trying the non-interactive sources first (see LookupForgeToken). If
+// none of those has a token, it interactively asks the user for one and
+// offers to persist it to ~/.netrc for next time. Only call this once
+// the caller has already committed to using the forge API, since it can
+// interrupt the user.
+func EnsureHasForgeToken(host string) (string, error) {
+	if token, ok := LookupForgeToken(host); ok {
+		return token, nil
+	}
+	return askForTokenAndPersist(host)
+}
+
+// LookupForgeToken returns an API token for the given forge host without
+// ever prompting the user, trying in order:
+//
+//  1. $GITHUB_TOKEN / $GITLAB_TOKEN
+//  2. ~/.netrc, keyed by host
+//  3. the Netscape cookie jar named by "git config --get http.cookiefile"
+//
+// It reports ok=false, with no error, when none of these sources has a
+// token - that is the expected outcome for users who haven't configured
+// forge API access, not a failure.
+func LookupForgeToken(host string) (token string, ok bool) {
+	if token = tokenFromEnv(host); token != "" {
+		return token, true
+	}
+	if token, err := tokenFromNetrc(host); err == nil && token != "" {
+		return token, true
+	}
+	if token, err := tokenFromCookieJar(host); err == nil && token != "" {
+		return token, true
+	}
+	return "", false
+}
+
+func tokenFromEnv(host string) string {
+	switch {
+	case strings.Contains(host, "github.com"):
+		return os.Getenv("GITHUB_TOKEN")
+	case strings.Contains(host, "gitlab.com"):
+		return os.Getenv("GITLAB_TOKEN")
+	default:
+		return ""
+	}
+}
+
+func tokenFromNetrc(host string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return tokenFromNetrcFile(filepath.Join(homeDir, ".netrc"), host)
+}
+
+func tokenFromNetrcFile(path, host string) (string, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	fields := strings.Fields(readAll(file))
+	for index, field := range fields {
+		if field == "machine" && index+1 < len(fields) && fields[index+1] == host {
+			return passwordAfterMachine(fields[index+2:]), nil
+		}
+	}
+	return "", nil
+}
+
+func passwordAfterMachine(fields []string) string {
+	for index, field := range fields {
+		if field == "password" && index+1 < len(fields) {
+			return fields[index+1]
+		}
+		if field == "machine" {
+			break
+		}
+	}
+	return ""
+}
+
+func readAll(file *os.File) string {
+	var builder strings.Builder
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		builder.WriteString(scanner.Text())
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// tokenFromCookieJar looks up the token in the Netscape cookie jar named
+// by "git config --get http.cookiefile", matching on the given host.
+func tokenFromCookieJar(host string) (string, error) {
+	cookieFile, err := script.NewGitCmd("config").Arg("--get", "http.cookiefile").RunStdString()
+	if err != nil || cookieFile == "" {
+		return "", nil //nolint:nilerr
+	}
+	file, err := os.Open(cookieFile)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") || line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) == 7 && strings.Contains(fields[0], host) {
+			return fields[6], nil
+		}
+	}
+	return "", nil
+}
+
+func askForTokenAndPersist(host string) (string, error) {
+	fmt.Printf("No API token found for %q. Please enter one: ", host)
+	token := readLine()
+	if token == "" {
+		return "", fmt.Errorf("no API token provided for %q", host)
+	}
+	fmt.Printf("Save this token to ~/.netrc for %q? [y/N] ", host)
+	if strings.EqualFold(readLine(), "y") {
+		if err := appendToNetrc(host, token); err != nil {
+			return "", err
+		}
+	}
+	return token, nil
+}
+
+func readLine() string {
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Scan()
+	return strings.TrimSpace(scanner.Text())
+}
+
+func appendToNetrc(host, token string) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(homeDir, ".netrc"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = fmt.Fprintf(file, "machine %s\n  login git-town\n  password %s\n", host, token)
+	return err
+}
@@ -0,0 +1,48 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeNetrc(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".netrc")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestTokenFromNetrcFile_FindsMatchingHost(t *testing.T) {
+	path := writeNetrc(t, "machine github.com\n  login git-town\n  password abc123\n")
+	token, err := tokenFromNetrcFile(path, "github.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "abc123" {
+		t.Errorf("token = %q, want %q", token, "abc123")
+	}
+}
+
+func TestTokenFromNetrcFile_IgnoresOtherHosts(t *testing.T) {
+	path := writeNetrc(t, "machine gitlab.com\n  login git-town\n  password abc123\n")
+	token, err := tokenFromNetrcFile(path, "github.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		t.Errorf("token = %q, want empty", token)
+	}
+}
+
+func TestTokenFromNetrcFile_MissingFileYieldsNoError(t *testing.T) {
+	token, err := tokenFromNetrcFile(filepath.Join(t.TempDir(), "does-not-exist"), "github.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		t.Errorf("token = %q, want empty", token)
+	}
+}
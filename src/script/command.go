@@ -0,0 +1,153 @@
+package script
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// GitCmd is a fluent builder for git invocations. It keeps literal
+// subcommand tokens (set via Arg) separate from dynamic, user-supplied
+// values such as branch names or commit messages (set via
+// AddDynamicArguments), so the latter can never be reinterpreted as a
+// flag by git.
+type GitCmd struct {
+	args          []string
+	dirOption     string
+	envOption     []string
+	dashDashAdded bool
+}
+
+// NewGitCmd starts building a "git <subcommand>" invocation.
+func NewGitCmd(subcommand string) *GitCmd {
+	return &GitCmd{args: []string{subcommand}}
+}
+
+// Arg appends a literal token, e.g. a flag or a constant subcommand
+// argument. Only use this for values the code itself chooses, never for
+// values that came from the user or from another branch/commit.
+func (c *GitCmd) Arg(args ...string) *GitCmd {
+	c.args = append(c.args, args...)
+	return c
+}
+
+// AddDynamicArguments appends user-supplied values, e.g. branch names,
+// commit messages, or refs. The first call inserts a "--" separator so
+// that a value like "-m" or "--force" is always parsed by git as a
+// positional argument rather than a flag.
+func (c *GitCmd) AddDynamicArguments(args ...string) *GitCmd {
+	if !c.dashDashAdded {
+		c.args = append(c.args, "--")
+		c.dashDashAdded = true
+	}
+	c.args = append(c.args, args...)
+	return c
+}
+
+// Dir runs the command in the given working directory instead of the
+// current one.
+func (c *GitCmd) Dir(dir string) *GitCmd {
+	c.dirOption = dir
+	return c
+}
+
+// Env adds "KEY=VALUE" entries to the command's environment, on top of
+// the current process environment.
+func (c *GitCmd) Env(env ...string) *GitCmd {
+	c.envOption = append(c.envOption, env...)
+	return c
+}
+
+func (c *GitCmd) argv() []string {
+	return append([]string{"git"}, c.args...)
+}
+
+// CmdError is returned when a GitCmd fails to run. It carries enough
+// context to produce a better diagnostic than a bare error.
+type CmdError struct {
+	Args   []string
+	Dir    string
+	Stdout string
+	Stderr string
+	Err    error
+}
+
+func (e *CmdError) Error() string {
+	return fmt.Sprintf("failed to run %q: %v", strings.Join(e.Args, " "), e.Err)
+}
+
+func (e *CmdError) Unwrap() error {
+	return e.Err
+}
+
+func (c *GitCmd) run(captureOutput bool) (stdout, stderr string, err error) {
+	argv := c.argv()
+	platformArgv := platformCommand(argv)
+	subProcess := exec.Command(platformArgv[0], platformArgv[1:]...)
+	if c.dirOption != "" {
+		subProcess.Dir = c.dirOption
+	}
+	if len(c.envOption) > 0 {
+		subProcess.Env = append(os.Environ(), c.envOption...)
+	}
+	subProcess.Stdin = os.Stdin
+	if captureOutput {
+		var outBuffer, errBuffer bytes.Buffer
+		subProcess.Stdout = &outBuffer
+		subProcess.Stderr = &errBuffer
+		err = subProcess.Run()
+		stdout, stderr = outBuffer.String(), errBuffer.String()
+	} else {
+		PrintCommand(argv...)
+		subProcess.Stdout = os.Stdout
+		subProcess.Stderr = os.Stderr
+		err = subProcess.Run()
+	}
+	if err != nil {
+		err = &CmdError{Args: argv, Dir: c.dirOption, Stdout: stdout, Stderr: stderr, Err: err}
+	}
+	return stdout, stderr, err
+}
+
+// Run executes the command, inheriting the terminal's stdout/stderr.
+func (c *GitCmd) Run() error {
+	_, _, err := c.run(false)
+	return err
+}
+
+// RunStdString executes the command and returns its trimmed stdout
+// without printing it to the terminal.
+func (c *GitCmd) RunStdString() (string, error) {
+	stdout, _, err := c.run(true)
+	return strings.TrimSpace(stdout), err
+}
+
+// RunStdBytes executes the command and returns its raw stdout without
+// printing it to the terminal.
+func (c *GitCmd) RunStdBytes() ([]byte, error) {
+	stdout, _, err := c.run(true)
+	return []byte(stdout), err
+}
+
+// platformCommand wraps argv so it runs correctly on the current
+// platform, e.g. routing it through "cmd /C" on Windows.
+func platformCommand(argv []string) []string {
+	if runtime.GOOS == "windows" {
+		return append([]string{"cmd", "/C"}, argv...)
+	}
+	return argv
+}
+
+// ShellCommandArgv returns the argv that runs command through the
+// current platform's shell: "cmd /C" on Windows, "sh -c" everywhere
+// else. Use this for arbitrary, user-authored command strings (e.g.
+// git-town.yml hooks) that aren't already split into argv form.
+func ShellCommandArgv(command string) []string {
+	if runtime.GOOS == "windows" {
+		return []string{"cmd", "/C", command}
+	}
+	return []string{"sh", "-c", command}
+}
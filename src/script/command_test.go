@@ -0,0 +1,30 @@
+package script
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitCmd_AddDynamicArguments_InsertsDashDashOnce(t *testing.T) {
+	cmd := NewGitCmd("branch").Arg("-D").AddDynamicArguments("-not-a-flag").AddDynamicArguments("other")
+	want := []string{"branch", "-D", "--", "-not-a-flag", "other"}
+	if got := cmd.argv()[1:]; !reflect.DeepEqual(got, want) {
+		t.Errorf("argv = %v, want %v", got, want)
+	}
+}
+
+func TestGitCmd_AddDynamicArguments_NotInsertedWithoutDynamicArgs(t *testing.T) {
+	cmd := NewGitCmd("status").Arg("--short")
+	want := []string{"status", "--short"}
+	if got := cmd.argv()[1:]; !reflect.DeepEqual(got, want) {
+		t.Errorf("argv = %v, want %v", got, want)
+	}
+}
+
+func TestGitCmd_Argv_PrependsGit(t *testing.T) {
+	cmd := NewGitCmd("status")
+	want := []string{"git", "status"}
+	if got := cmd.argv(); !reflect.DeepEqual(got, want) {
+		t.Errorf("argv = %v, want %v", got, want)
+	}
+}
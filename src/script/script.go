@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"runtime"
 	"strings"
 
 	"github.com/Originate/git-town/src/exit"
@@ -44,11 +43,7 @@ func PrintCommand(cmd ...string) {
 // RunCommand executes the given command-line operation.
 func RunCommand(cmd ...string) error {
 	PrintCommand(cmd...)
-	// Windows commands run inside CMD
-	// because opening browsers is done via "start"
-	if runtime.GOOS == "windows" {
-		cmd = append([]string{"cmd", "/C"}, cmd...)
-	}
+	cmd = platformCommand(cmd)
 	subProcess := exec.Command(cmd[0], cmd[1:]...)
 	subProcess.Stderr = os.Stderr
 	subProcess.Stdin = os.Stdin
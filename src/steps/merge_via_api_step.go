@@ -0,0 +1,125 @@
+package steps
+
+import (
+	"fmt"
+
+	"github.com/Originate/git-town/src/forge"
+)
+
+// MergeViaAPIStep merges the open pull/merge request from BranchName
+// into TargetBranch through the configured forge's API, instead of
+// performing a local squash-merge and push.
+type MergeViaAPIStep struct {
+	EmptyStep
+	BranchName        string
+	TargetBranch      string
+	CommitMessage     string
+	PullRequestNumber int
+	PullRequestTitle  string
+	PullRequestBody   string
+}
+
+func (step MergeViaAPIStep) CreateUndoStep() (Step, error) {
+	return NoOpStep{}, nil
+}
+
+func (step MergeViaAPIStep) Run() error {
+	driver, err := forge.DriverForOrigin()
+	if err != nil {
+		return err
+	}
+	commitMessage := step.CommitMessage
+	if commitMessage == "" {
+		commitMessage = step.PullRequestTitle + "\n\n" + step.PullRequestBody
+	}
+	return driver.SquashMergePullRequest(step.PullRequestNumber, commitMessage)
+}
+
+// ShipStep finishes "git town ship" for BranchName: it merges it into
+// TargetBranch, via the forge's API when Strategy calls for it and a
+// pull request is found, or via a local squash-merge (and a push to
+// Remote, if Remote isn't empty) otherwise. Which of those happens is
+// resolved here, inside Run(), rather than while the step list is being
+// built - forge lookups (and, for Strategy "api", token prompts) are
+// real I/O, and running them at step-list-generation time would repeat
+// them, and could re-prompt, every time the list is regenerated (e.g. on
+// "--continue").
+type ShipStep struct {
+	EmptyStep
+	BranchName    string
+	TargetBranch  string
+	Remote        string
+	CommitMessage string
+	Strategy      string
+}
+
+func (step ShipStep) CreateUndoStep() (Step, error) {
+	return NoOpStep{}, nil
+}
+
+func (step ShipStep) Run() error {
+	pullRequest, err := resolvePullRequestForShip(step.Strategy, step.BranchName, step.TargetBranch)
+	if err != nil {
+		return err
+	}
+	if pullRequest != nil {
+		return MergeViaAPIStep{
+			BranchName:        step.BranchName,
+			TargetBranch:      step.TargetBranch,
+			CommitMessage:     step.CommitMessage,
+			PullRequestNumber: pullRequest.Number,
+			PullRequestTitle:  pullRequest.Title,
+			PullRequestBody:   pullRequest.Body,
+		}.Run()
+	}
+	if err = (SquashMergeBranchStep{BranchName: step.BranchName, CommitMessage: step.CommitMessage}).Run(); err != nil {
+		return err
+	}
+	if step.Remote == "" {
+		return nil
+	}
+	return PushBranchStep{BranchName: step.TargetBranch, Remote: step.Remote, Undoable: true}.Run()
+}
+
+// resolvePullRequestForShip looks up the pull request that ShipStep
+// should merge via the API for branchName, honoring the given
+// ship.strategy:
+//
+//   - "squash-merge" never uses the API: it returns (nil, nil).
+//   - "api" requires an open pull request to exist; resolving credentials
+//     for it may prompt the user, since this is an explicit opt-in, and
+//     any lookup failure is returned as an error rather than silently
+//     falling back to a local squash-merge.
+//   - "auto" (the default) only uses the API when a forge token is
+//     already available without prompting; with no token available it
+//     returns (nil, nil) so the caller falls back to a local
+//     squash-merge without interrupting users who never opted in.
+func resolvePullRequestForShip(strategy, branchName, targetBranch string) (*forge.PullRequest, error) {
+	switch strategy {
+	case "squash-merge":
+		return nil, nil
+	case "api":
+		driver, err := forge.DriverForOrigin()
+		if err != nil {
+			return nil, err
+		}
+		pullRequest, err := driver.FindPullRequest(branchName, targetBranch)
+		if err != nil {
+			return nil, fmt.Errorf("cannot determine the pull request for %q: %w", branchName, err)
+		}
+		if pullRequest == nil {
+			return nil, fmt.Errorf("ship.strategy is \"api\" but %q has no open pull request", branchName)
+		}
+		return pullRequest, nil
+	default:
+		driver, ok, err := forge.DriverForOriginIfTokenAvailable()
+		if err != nil || !ok {
+			return nil, err
+		}
+		pullRequest, err := driver.FindPullRequest(branchName, targetBranch)
+		if err != nil {
+			return nil, fmt.Errorf("cannot determine the pull request for %q: %w", branchName, err)
+		}
+		return pullRequest, nil
+	}
+}
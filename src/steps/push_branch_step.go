@@ -0,0 +1,43 @@
+package steps
+
+import (
+	"github.com/Originate/git-town/src/git"
+	"github.com/Originate/git-town/src/script"
+)
+
+// PushBranchStep pushes BranchName to Remote, or to the branch's
+// configured push target (see git.PushTarget) if Remote is empty. When
+// SetUpstream is true, it records the branch's upstream via "push -u" so
+// that plain "git push"/"git pull" work afterwards.
+type PushBranchStep struct {
+	EmptyStep
+	BranchName  string
+	Remote      string
+	SetUpstream bool
+	Undoable    bool
+}
+
+func (step PushBranchStep) Run() error {
+	remote := step.Remote
+	if remote == "" {
+		remote = git.PushTarget(step.BranchName).Remote
+	}
+	cmd := script.NewGitCmd("push")
+	if step.SetUpstream {
+		cmd.Arg("-u")
+	}
+	cmd.AddDynamicArguments(remote, step.BranchName)
+	return cmd.Run()
+}
+
+// CreateUndoStep removes the upstream this step recorded, if any, so
+// that "--undo" leaves the branch without a tracking branch again.
+func (step PushBranchStep) CreateUndoStep() (Step, error) {
+	if !step.Undoable {
+		return NoOpStep{}, nil
+	}
+	if step.SetUpstream {
+		return RemoveUpstreamStep{BranchName: step.BranchName}, nil
+	}
+	return NoOpStep{}, nil
+}
@@ -0,0 +1,22 @@
+package steps
+
+import "github.com/Originate/git-town/src/script"
+
+// RemoveUpstreamStep removes the "branch.<name>.remote" and
+// "branch.<name>.merge" config entries, undoing the upstream that a
+// "git push -u" recorded for a branch.
+type RemoveUpstreamStep struct {
+	EmptyStep
+	BranchName string
+}
+
+func (step RemoveUpstreamStep) CreateUndoStep() (Step, error) {
+	return NoOpStep{}, nil
+}
+
+func (step RemoveUpstreamStep) Run() error {
+	if err := script.NewGitCmd("config").Arg("--unset", "branch."+step.BranchName+".remote").Run(); err != nil {
+		return err
+	}
+	return script.NewGitCmd("config").Arg("--unset", "branch."+step.BranchName+".merge").Run()
+}
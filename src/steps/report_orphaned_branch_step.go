@@ -0,0 +1,20 @@
+package steps
+
+import "fmt"
+
+// ReportOrphanedBranchStep prints a warning for a branch whose upstream
+// is gone but that still has unmerged work, so "git town sync-all"
+// leaves it alone instead of silently deleting it.
+type ReportOrphanedBranchStep struct {
+	EmptyStep
+	BranchName string
+}
+
+func (step ReportOrphanedBranchStep) CreateUndoStep() (Step, error) {
+	return NoOpStep{}, nil
+}
+
+func (step ReportOrphanedBranchStep) Run() error {
+	fmt.Printf("branch %q has no upstream and hasn't been merged into the main branch - leaving it alone\n", step.BranchName)
+	return nil
+}
@@ -0,0 +1,54 @@
+package steps
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/Originate/git-town/src/hooks"
+	"github.com/Originate/git-town/src/script"
+)
+
+// RunHookStep runs every command registered for Event in .git-town.yml,
+// in order. A non-zero exit from any command aborts the step list the
+// same way an internal step failure does, so hooks participate in
+// "--abort", "--continue", and "--undo" like any other step.
+type RunHookStep struct {
+	EmptyStep
+	Event string
+	Data  hooks.Data
+}
+
+func (step RunHookStep) CreateUndoStep() (Step, error) {
+	return NoOpStep{}, nil
+}
+
+func (step RunHookStep) Run() error {
+	config, err := hooks.Load(".")
+	if err != nil {
+		return err
+	}
+	for _, command := range config.CommandsFor(step.Event) {
+		if err = step.runCommand(command); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (step RunHookStep) runCommand(command string) error {
+	expanded, err := hooks.Evaluate(command, step.Data)
+	if err != nil {
+		return fmt.Errorf("cannot evaluate %q hook %q: %w", step.Event, command, err)
+	}
+	argv := script.ShellCommandArgv(expanded)
+	subProcess := exec.Command(argv[0], argv[1:]...)
+	subProcess.Env = append(os.Environ(), step.Data.Env()...)
+	subProcess.Stdin = os.Stdin
+	subProcess.Stdout = os.Stdout
+	subProcess.Stderr = os.Stderr
+	if err = subProcess.Run(); err != nil {
+		return fmt.Errorf("%q hook %q failed: %w", step.Event, command, err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package steps
+
+import "github.com/Originate/git-town/src/git"
+
+// GetSyncAllBranchSteps returns the steps needed to bring a single local
+// branch up to date as part of "git town sync-all", along with whether
+// those steps delete the branch. currentBranch is the branch that is
+// checked out before "sync-all" starts, so a deletion can check it out
+// elsewhere first: git refuses to delete the currently checked-out
+// branch.
+func GetSyncAllBranchSteps(branchName, mainBranch, currentBranch string) (result StepList, deletesBranch bool) {
+	if branchName == mainBranch {
+		// The main branch is never deleted or reparented by sync-all,
+		// even if its upstream happens to be gone.
+		if git.HasTrackingBranch(branchName) && !git.HasUnpushedCommits(branchName) {
+			result.Append(CheckoutBranchStep{BranchName: branchName})
+			result.AppendList(GetSyncBranchSteps(branchName))
+		}
+		return result, false
+	}
+	if !git.HasTrackingBranch(branchName) {
+		return getDeletedUpstreamBranchSteps(branchName, mainBranch, currentBranch)
+	}
+	if git.HasUnpushedCommits(branchName) {
+		return result, false
+	}
+	result.Append(CheckoutBranchStep{BranchName: branchName})
+	result.AppendList(GetSyncBranchSteps(branchName))
+	return result, false
+}
+
+// getDeletedUpstreamBranchSteps handles a branch whose upstream is gone:
+// if it has already been merged into mainBranch, delete it and reparent
+// its children onto its former parent; otherwise leave it alone and
+// report it. If branchName is the currently checked-out branch, it is
+// checked out away from first, since git refuses to delete the current
+// branch.
+func getDeletedUpstreamBranchSteps(branchName, mainBranch, currentBranch string) (result StepList, deletesBranch bool) {
+	if !git.IsBranchMerged(branchName, mainBranch) {
+		result.Append(ReportOrphanedBranchStep{BranchName: branchName})
+		return result, false
+	}
+	if branchName == currentBranch {
+		result.Append(CheckoutBranchStep{BranchName: mainBranch})
+	}
+	parentBranch := git.GetParentBranch(branchName)
+	childBranches := git.GetChildBranches(branchName)
+	result.Append(DeleteLocalBranchStep{BranchName: branchName})
+	result.Append(DeleteParentBranchStep{BranchName: branchName})
+	for _, child := range childBranches {
+		result.Append(SetParentBranchStep{BranchName: child, ParentBranchName: parentBranch})
+	}
+	return result, true
+}